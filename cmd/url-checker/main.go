@@ -2,7 +2,10 @@ package main
 
 import (
 	"bufio"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 )
 
 // main is the entry point of the application.
@@ -35,3 +38,21 @@ func readURLs(path string) ([]string, error) {
 
 	return urls, nil
 }
+
+// parseHeaders converts repeated "Name:Value" strings (as collected by the
+// --header flag) into an http.Header.
+func parseHeaders(raw []string) (http.Header, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	headers := make(http.Header)
+	for _, h := range raw {
+		name, value, ok := strings.Cut(h, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid --header %q: expected Name:Value", h)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
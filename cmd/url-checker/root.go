@@ -5,15 +5,22 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/sudosantos27/go-url-checker/internal/checker"
+	"github.com/sudosantos27/go-url-checker/internal/telemetry"
 )
 
 var (
 	cfgFile string
+
+	// otelShutdown flushes and stops the global TracerProvider. It is a
+	// no-op until PersistentPreRun initializes tracing.
+	otelShutdown func(context.Context) error = func(context.Context) error { return nil }
 )
 
 // rootCmd represents the base command when called without any subcommands.
@@ -43,6 +50,19 @@ It supports:
 		// Use TextHandler writing to Stderr to avoid polluting stdout (JSON output)
 		logger := slog.New(slog.NewTextHandler(os.Stderr, opts))
 		slog.SetDefault(logger)
+
+		// Initialize OpenTelemetry tracing. shutdown must be called before
+		// the process exits so buffered spans are flushed.
+		shutdown, err := telemetry.Init(cmd.Context(), telemetry.Config{
+			Exporter:    viper.GetString("otel-exporter"),
+			Endpoint:    viper.GetString("otel-endpoint"),
+			SampleRatio: viper.GetFloat64("otel-sample-ratio"),
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error initializing OpenTelemetry: %v\n", err)
+			os.Exit(1)
+		}
+		otelShutdown = shutdown
 	},
 
 	// Run contains the main logic of the command.
@@ -55,6 +75,16 @@ It supports:
 		output := viper.GetString("output")
 		retries := viper.GetInt("retries")
 		rateLimit := viper.GetInt("rate-limit")
+		urlTimeout := viper.GetDuration("url-timeout")
+		method := viper.GetString("method")
+		body := viper.GetString("body")
+		failIfCertExpiresWithin := viper.GetDuration("fail-if-cert-expires-within")
+
+		headers, err := parseHeaders(viper.GetStringSlice("header"))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		// Validation: Ensure the input file is specified.
 		if file == "" {
@@ -81,17 +111,44 @@ It supports:
 			return
 		}
 
-		// Create a context with a global timeout.
-		// This context will be propagated to all workers and HTTP requests.
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		// Build a cancelable root context so a SIGINT/SIGTERM can carry its
+		// own cause, distinct from the global timeout below.
+		rootCtx, cancelRoot := context.WithCancelCause(context.Background())
+		defer cancelRoot(nil)
+
+		sigCh := make(chan os.Signal, 1)
+		signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			if _, ok := <-sigCh; ok {
+				cancelRoot(checker.ErrUserInterrupt)
+			}
+		}()
+		defer signal.Stop(sigCh)
+
+		// Layer the global timeout on top, with its own cause so
+		// Result.FailureReason can tell it apart from a per-URL timeout.
+		ctx, cancel := context.WithTimeoutCause(rootCtx, timeout, checker.ErrGlobalTimeout)
 		defer cancel()
 
+		// --wait mode repeatedly re-checks URLs against a success predicate
+		// instead of checking each one once; it has its own timeout and
+		// reporting, so it takes over here and returns.
+		if viper.GetBool("wait") {
+			runWait(rootCtx, urls, concurrency, viper.GetDuration("wait-timeout"), output)
+			return
+		}
+
 		// Initialize configuration struct for the checker.
 		cfg := checker.Config{
-			Concurrency: concurrency,
-			Timeout:     timeout,
-			Retries:     retries,
-			RateLimit:   rateLimit,
+			Concurrency:             concurrency,
+			Timeout:                 timeout,
+			Retries:                 retries,
+			RateLimit:               rateLimit,
+			PerURLTimeout:           urlTimeout,
+			Method:                  method,
+			Body:                    body,
+			Headers:                 headers,
+			FailIfCertExpiresWithin: failIfCertExpiresWithin,
 		}
 
 		// Execute the URL checker logic.
@@ -102,12 +159,35 @@ It supports:
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+
+	// Flush any buffered spans before the process exits, regardless of
+	// whether the command succeeded.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := otelShutdown(shutdownCtx); shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "Error shutting down OpenTelemetry: %v\n", shutdownErr)
+	}
+
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
 
+// exitAfterShutdown flushes any buffered OTel spans and then exits with
+// code. Call this instead of a bare os.Exit from anywhere that runs after
+// PersistentPreRun has initialized tracing (e.g. --wait mode, which calls
+// os.Exit directly instead of returning through Execute()).
+func exitAfterShutdown(code int) {
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if shutdownErr := otelShutdown(shutdownCtx); shutdownErr != nil {
+		fmt.Fprintf(os.Stderr, "Error shutting down OpenTelemetry: %v\n", shutdownErr)
+	}
+	os.Exit(code)
+}
+
 // init initializes the flags and configuration settings.
 func init() {
 	cobra.OnInitialize(initConfig)
@@ -123,6 +203,20 @@ func init() {
 	rootCmd.Flags().Bool("debug", false, "Enable debug logging")
 	rootCmd.Flags().Int("retries", 0, "Number of retries for failed requests")
 	rootCmd.Flags().Int("rate-limit", 0, "Rate limit in requests per second (0 = unlimited)")
+	rootCmd.Flags().Duration("url-timeout", 0, "Per-URL deadline for each check attempt (0 = disabled, only the global --timeout applies)")
+	rootCmd.Flags().Bool("wait", false, "Wait mode: re-check URLs until they become healthy instead of checking once")
+	rootCmd.Flags().Duration("wait-timeout", 5*time.Minute, "Maximum time to wait for all URLs to become healthy (--wait mode)")
+	rootCmd.Flags().String("expect-status", "", "Comma-separated status codes that count as healthy (--wait mode; default: any 2xx)")
+	rootCmd.Flags().String("expect-body-regex", "", "Response body must match this regex to count as healthy (--wait mode)")
+	rootCmd.Flags().String("expect-header", "", "Name:regex the named response header must match to count as healthy (--wait mode)")
+	rootCmd.Flags().Int("min-consecutive-successes", 1, "Consecutive successful checks required before a URL counts as healthy (--wait mode)")
+	rootCmd.Flags().String("method", "GET", "HTTP method to use for http(s) URLs (GET, HEAD, POST, ...)")
+	rootCmd.Flags().String("body", "", "Request body to send for http(s) URLs (e.g. with --method POST)")
+	rootCmd.Flags().StringArray("header", nil, "Extra request header for http(s) URLs, as Name:Value (repeatable)")
+	rootCmd.Flags().Duration("fail-if-cert-expires-within", 0, "For tls:// URLs, fail the check if the certificate expires within this duration (0 = disabled)")
+	rootCmd.PersistentFlags().String("otel-exporter", "none", "OpenTelemetry span exporter (otlp, stdout, none)")
+	rootCmd.PersistentFlags().String("otel-endpoint", "localhost:4317", "OTLP collector endpoint (host:port)")
+	rootCmd.PersistentFlags().Float64("otel-sample-ratio", 1.0, "Fraction of traces to sample (0.0-1.0)")
 
 	// Bind flags to viper to enable environment variable and config file support.
 	_ = viper.BindPFlag("file", rootCmd.Flags().Lookup("file"))
@@ -132,6 +226,20 @@ func init() {
 	_ = viper.BindPFlag("debug", rootCmd.Flags().Lookup("debug"))
 	_ = viper.BindPFlag("retries", rootCmd.Flags().Lookup("retries"))
 	_ = viper.BindPFlag("rate-limit", rootCmd.Flags().Lookup("rate-limit"))
+	_ = viper.BindPFlag("url-timeout", rootCmd.Flags().Lookup("url-timeout"))
+	_ = viper.BindPFlag("wait", rootCmd.Flags().Lookup("wait"))
+	_ = viper.BindPFlag("wait-timeout", rootCmd.Flags().Lookup("wait-timeout"))
+	_ = viper.BindPFlag("expect-status", rootCmd.Flags().Lookup("expect-status"))
+	_ = viper.BindPFlag("expect-body-regex", rootCmd.Flags().Lookup("expect-body-regex"))
+	_ = viper.BindPFlag("expect-header", rootCmd.Flags().Lookup("expect-header"))
+	_ = viper.BindPFlag("min-consecutive-successes", rootCmd.Flags().Lookup("min-consecutive-successes"))
+	_ = viper.BindPFlag("method", rootCmd.Flags().Lookup("method"))
+	_ = viper.BindPFlag("body", rootCmd.Flags().Lookup("body"))
+	_ = viper.BindPFlag("header", rootCmd.Flags().Lookup("header"))
+	_ = viper.BindPFlag("fail-if-cert-expires-within", rootCmd.Flags().Lookup("fail-if-cert-expires-within"))
+	_ = viper.BindPFlag("otel-exporter", rootCmd.PersistentFlags().Lookup("otel-exporter"))
+	_ = viper.BindPFlag("otel-endpoint", rootCmd.PersistentFlags().Lookup("otel-endpoint"))
+	_ = viper.BindPFlag("otel-sample-ratio", rootCmd.PersistentFlags().Lookup("otel-sample-ratio"))
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/sudosantos27/go-url-checker/internal/server"
+)
+
+// serveCmd boots a long-running HTTP service exposing the checker as
+// /v1/check, /v1/check/stream, /metrics and /healthz.
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run the URL checker as an HTTP service",
+	Long: `serve boots a long-running HTTP server exposing the checker:
+
+- POST /v1/check          run a batch of checks and return the full result list
+- GET  /v1/check/stream   stream results as NDJSON as they arrive
+- GET  /metrics           Prometheus metrics
+- GET  /healthz           liveness probe
+
+It shuts down gracefully on SIGINT/SIGTERM, draining in-flight checks.
+
+SECURITY: --auth-token is empty (no authentication) by default. Since the
+checker can also dial tcp://, tls://, and dns:// targets, an unauthenticated
+caller can use this endpoint to scan or resolve anything reachable from the
+server host. Always set --auth-token when --addr binds to anything other
+than loopback; serve refuses to start otherwise.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr := viper.GetString("addr")
+		authToken := viper.GetString("auth-token")
+
+		if authToken == "" && !isLoopbackAddr(addr) {
+			fmt.Fprintf(os.Stderr, "Error: refusing to bind %s with no --auth-token set; this server can dial tcp://, tls://, and dns:// targets, so an unauthenticated caller could use it to scan your network. Set --auth-token, or bind a loopback --addr for local-only access.\n", addr)
+			os.Exit(1)
+		}
+
+		cfg := server.Config{
+			Addr:      addr,
+			AuthToken: authToken,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		if err := server.New(cfg).Run(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running server: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// isLoopbackAddr reports whether addr's host (an "addr:port" listen
+// address, as accepted by --addr) is the literal "localhost" or a loopback
+// IP. Other host names are not resolved and count as non-loopback.
+// A missing host (e.g. ":8080") binds every interface and is not
+// considered loopback.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	if host == "" {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", ":8080", "Address to listen on")
+	serveCmd.Flags().String("auth-token", "", "Bearer token required on /v1/check and /v1/check/stream (SECURITY: empty means no authentication; required unless --addr is loopback)")
+
+	_ = viper.BindPFlag("addr", serveCmd.Flags().Lookup("addr"))
+	_ = viper.BindPFlag("auth-token", serveCmd.Flags().Lookup("auth-token"))
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/sudosantos27/go-url-checker/internal/checker"
+)
+
+// runWait drives the checker.Waiter for --wait mode: it polls urls until
+// every one satisfies the configured SuccessPredicate or --wait-timeout
+// elapses, then reports per-URL results and exits non-zero if any URL
+// never became healthy.
+func runWait(ctx context.Context, urls []string, concurrency int, waitTimeout time.Duration, outputFormat string) {
+	predicate, err := buildSuccessPredicate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exitAfterShutdown(1)
+	}
+
+	waiter := checker.NewWaiter(checker.WaiterConfig{
+		Concurrency: concurrency,
+		WaitTimeout: waitTimeout,
+		Predicate:   predicate,
+	})
+
+	results := waiter.Wait(ctx, urls)
+
+	allHealthy := true
+	for _, res := range results {
+		if !res.BecameHealthy {
+			allHealthy = false
+		}
+	}
+
+	if outputFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding JSON: %v\n", err)
+		}
+	} else {
+		for _, res := range results {
+			status := "unhealthy"
+			if res.BecameHealthy {
+				status = "healthy"
+			}
+			fmt.Printf("%s: %s (attempts=%d)\n", res.URL, status, res.Attempts)
+		}
+	}
+
+	if !allHealthy {
+		exitAfterShutdown(1)
+	}
+}
+
+// buildSuccessPredicate reads the --expect-* and --min-consecutive-successes
+// flags via viper and assembles a checker.SuccessPredicate.
+func buildSuccessPredicate() (checker.SuccessPredicate, error) {
+	var predicate checker.SuccessPredicate
+
+	if raw := viper.GetString("expect-status"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			code, err := strconv.Atoi(part)
+			if err != nil {
+				return predicate, fmt.Errorf("invalid --expect-status value %q: %w", part, err)
+			}
+			predicate.ExpectStatus = append(predicate.ExpectStatus, code)
+		}
+	}
+
+	if raw := viper.GetString("expect-body-regex"); raw != "" {
+		re, err := regexp.Compile(raw)
+		if err != nil {
+			return predicate, fmt.Errorf("invalid --expect-body-regex: %w", err)
+		}
+		predicate.ExpectBodyRegex = re
+	}
+
+	if raw := viper.GetString("expect-header"); raw != "" {
+		name, pattern, ok := strings.Cut(raw, ":")
+		if !ok {
+			return predicate, fmt.Errorf("invalid --expect-header %q: expected Name:regex", raw)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return predicate, fmt.Errorf("invalid --expect-header regex: %w", err)
+		}
+		predicate.ExpectHeaderName = strings.TrimSpace(name)
+		predicate.ExpectHeaderRegex = re
+	}
+
+	predicate.MinConsecutive = viper.GetInt("min-consecutive-successes")
+
+	return predicate, nil
+}
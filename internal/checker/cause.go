@@ -0,0 +1,82 @@
+package checker
+
+import (
+	"context"
+	"errors"
+)
+
+// Cancellation causes used with context.WithCancelCause/WithTimeoutCause so
+// that Result.Err and Result.FailureReason can distinguish why a check
+// actually stopped instead of reporting a generic "context deadline
+// exceeded" / "context canceled" for every case.
+var (
+	// ErrGlobalTimeout is the cause attached to the root context's
+	// deadline (the CLI's --timeout / the server request's timeout).
+	ErrGlobalTimeout = errors.New("global timeout exceeded")
+	// ErrURLTimeout is the cause attached to a single check attempt's
+	// deadline (--url-timeout).
+	ErrURLTimeout = errors.New("per-URL timeout exceeded")
+	// ErrUserInterrupt is the cause used to cancel the root context when
+	// the process receives SIGINT/SIGTERM.
+	ErrUserInterrupt = errors.New("interrupted by user")
+	// ErrRateLimitAborted is the cause reported when a rate-limiter wait
+	// fails while the context is still live (the limiter gives up because
+	// the wait would outlast the deadline). A wait cut short by
+	// cancellation reports the context's own cause instead.
+	ErrRateLimitAborted = errors.New("rate limiter wait aborted")
+)
+
+// ErrCertExpiringSoon is wrapped into a tls:// probe's Result.Err when the
+// certificate's expiry trips --fail-if-cert-expires-within. It is not a
+// connectivity failure, so shouldRetry treats it as non-retryable:
+// redialing the same server can't change when its certificate expires.
+var ErrCertExpiringSoon = errors.New("certificate expires within threshold")
+
+// FailureReason classifies why a check did not succeed, so JSON consumers
+// can branch on a stable enum instead of string-matching ErrorMsg.
+type FailureReason string
+
+const (
+	// FailureNone means the check succeeded.
+	FailureNone FailureReason = ""
+	// FailureNetwork covers connection/DNS/TLS errors unrelated to a
+	// deadline (e.g. connection refused).
+	FailureNetwork FailureReason = "network"
+	// FailureTimeoutURL means the per-URL timeout (--url-timeout) elapsed.
+	FailureTimeoutURL FailureReason = "timeout_url"
+	// FailureTimeoutGlobal means the global timeout (--timeout) elapsed.
+	FailureTimeoutGlobal FailureReason = "timeout_global"
+	// FailureCanceled means the check was aborted by cancellation that
+	// isn't a timeout (user interrupt, or a parent context canceling).
+	FailureCanceled FailureReason = "canceled"
+	// FailureHTTP5xx means the server responded with a 5xx status.
+	FailureHTTP5xx FailureReason = "http_5xx"
+	// FailureHTTP4xx means the server responded with a 4xx status.
+	FailureHTTP4xx FailureReason = "http_4xx"
+	// FailureCertExpiring means a tls:// probe succeeded but its
+	// certificate expires within --fail-if-cert-expires-within.
+	FailureCertExpiring FailureReason = "cert_expiring"
+)
+
+// classifyCause maps a context cancellation cause to a FailureReason.
+func classifyCause(cause error) FailureReason {
+	switch {
+	case errors.Is(cause, ErrURLTimeout):
+		return FailureTimeoutURL
+	case errors.Is(cause, ErrGlobalTimeout):
+		return FailureTimeoutGlobal
+	case errors.Is(cause, context.DeadlineExceeded):
+		return FailureTimeoutGlobal
+	default:
+		return FailureCanceled
+	}
+}
+
+// causeOf returns the most specific available reason ctx stopped: its
+// recorded cancellation Cause if one was set, otherwise its plain Err.
+func causeOf(ctx context.Context) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,56 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestClassifyCause_URLTimeout(t *testing.T) {
+	if got := classifyCause(ErrURLTimeout); got != FailureTimeoutURL {
+		t.Errorf("Expected %q, got: %q", FailureTimeoutURL, got)
+	}
+}
+
+func TestClassifyCause_GlobalTimeout(t *testing.T) {
+	if got := classifyCause(ErrGlobalTimeout); got != FailureTimeoutGlobal {
+		t.Errorf("Expected %q, got: %q", FailureTimeoutGlobal, got)
+	}
+}
+
+func TestClassifyCause_DeadlineExceeded(t *testing.T) {
+	if got := classifyCause(context.DeadlineExceeded); got != FailureTimeoutGlobal {
+		t.Errorf("Expected %q, got: %q", FailureTimeoutGlobal, got)
+	}
+}
+
+func TestClassifyCause_UserInterrupt(t *testing.T) {
+	if got := classifyCause(ErrUserInterrupt); got != FailureCanceled {
+		t.Errorf("Expected %q, got: %q", FailureCanceled, got)
+	}
+}
+
+func TestClassifyCause_WrappedURLTimeout(t *testing.T) {
+	wrapped := errors.Join(errors.New("attempt failed"), ErrURLTimeout)
+	if got := classifyCause(wrapped); got != FailureTimeoutURL {
+		t.Errorf("Expected wrapped cause to still classify as %q, got: %q", FailureTimeoutURL, got)
+	}
+}
+
+func TestCauseOf_PrefersRecordedCause(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(ErrUserInterrupt)
+
+	if got := causeOf(ctx); !errors.Is(got, ErrUserInterrupt) {
+		t.Errorf("Expected causeOf to return the recorded cause, got: %v", got)
+	}
+}
+
+func TestCauseOf_FallsBackToErr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if got := causeOf(ctx); !errors.Is(got, context.Canceled) {
+		t.Errorf("Expected causeOf to fall back to ctx.Err(), got: %v", got)
+	}
+}
@@ -3,6 +3,7 @@ package checker
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
@@ -11,45 +12,105 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 )
 
+// tracer emits the spans produced for each per-URL check attempt.
+var tracer = otel.Tracer("github.com/sudosantos27/go-url-checker/internal/checker")
+
 // Result represents the outcome of a single URL check operation.
 // It includes metadata such as the status code, duration, and any error encountered.
 // This struct is tagged for JSON serialization to support structured output.
 type Result struct {
-	URL        string        `json:"url"`
-	StatusCode int           `json:"status_code"`
-	Duration   time.Duration `json:"duration_ns"` // Duration in nanoseconds for JSON
-	Retries    int           `json:"retries"`     // Number of retries performed
-	Err        error         `json:"-"`           // Skip error interface
-	ErrorMsg   string        `json:"error,omitempty"`
+	URL           string        `json:"url"`
+	Protocol      string        `json:"protocol,omitempty"` // "http", "tcp", "tls", or "dns"; empty means http for results predating this field
+	Method        string        `json:"method,omitempty"`   // resolved HTTP method (e.g. GET, HEAD, POST); set by the http(s) prober
+	StatusCode    int           `json:"status_code"`
+	Duration      time.Duration `json:"duration_ns"` // Duration in nanoseconds for JSON
+	Retries       int           `json:"retries"`     // Number of retries performed
+	Err           error         `json:"-"`           // Skip error interface
+	ErrorMsg      string        `json:"error,omitempty"`
+	FailureReason FailureReason `json:"failure_reason,omitempty"`
+	RateLimitWait time.Duration `json:"-"`                       // Time spent waiting on the rate limiter; consumed by callers that export metrics
+	TLSNotAfter   *time.Time    `json:"tls_not_after,omitempty"` // set by the tls:// prober
+	DNSAnswers    []string      `json:"dns_answers,omitempty"`   // set by the dns:// prober
+}
+
+// Failed reports whether this Result represents a failed check, using
+// protocol-appropriate success semantics: HTTP requires a 2xx status,
+// while TCP/TLS/DNS only require the probe to have completed without error.
+func (r Result) Failed() bool {
+	if r.Err != nil {
+		return true
+	}
+	if r.Protocol == "http" || r.Protocol == "" {
+		return r.StatusCode < 200 || r.StatusCode >= 300
+	}
+	return false
+}
+
+// shouldRetry reports whether checkURLWithRetries should attempt res's
+// check again: always on error, and on 5xx for the HTTP protocol (the
+// other protocols have no analogous "server is having a bad day" status).
+// The one exception is a certificate-expiry breach, which redialing can't
+// fix, so it is never retried.
+func shouldRetry(res Result) bool {
+	if res.Err != nil {
+		return !errors.Is(res.Err, ErrCertExpiringSoon)
+	}
+	if res.Protocol == "http" || res.Protocol == "" {
+		return res.StatusCode >= 500
+	}
+	return false
 }
 
 // Config holds the configuration parameters for the URL checker.
 // It controls concurrency levels, timeouts, retry policies, and rate limiting.
 type Config struct {
-	Concurrency int           // Number of concurrent workers
-	Timeout     time.Duration // Global timeout context (not used directly in struct, but good for context)
-	Retries     int           // Maximum number of retries for failed requests
-	RateLimit   int           // Rate limit in requests per second (0 = unlimited)
+	Concurrency   int           // Number of concurrent workers
+	Timeout       time.Duration // Global timeout context (not used directly in struct, but good for context)
+	Retries       int           // Maximum number of retries for failed requests
+	RateLimit     int           // Rate limit in requests per second (0 = unlimited)
+	PerURLTimeout time.Duration // Per-attempt deadline applied to each checkURL call (0 = disabled)
+
+	// Method, Body and Headers configure the HTTP prober for plain
+	// http(s) URLs; tcp://, tls:// and dns:// URLs ignore them.
+	Method  string
+	Body    string
+	Headers http.Header
+
+	// FailIfCertExpiresWithin marks a tls:// probe as failed when the
+	// leaf certificate's NotAfter is closer than this duration.
+	FailIfCertExpiresWithin time.Duration
 }
 
-// Check is the main entry point for the URL checking logic.
-// It initializes the worker pool, manages the channels for jobs and results,
-// and handles the aggregation of statistics.
-//
-// Parameters:
-//   - ctx: Context for global cancellation and timeout.
-//   - urls: Slice of URL strings to check.
-//   - cfg: Configuration object containing concurrency, retry, and rate limit settings.
-//   - outputFormat: Format for the final output ("text" or "json").
-func Check(ctx context.Context, urls []string, cfg Config, outputFormat string) {
+// probeOptions converts the relevant fields of cfg into a ProbeOptions.
+func (cfg Config) probeOptions() ProbeOptions {
+	return ProbeOptions{
+		Method:                  cfg.Method,
+		Body:                    cfg.Body,
+		Headers:                 cfg.Headers,
+		FailIfCertExpiresWithin: cfg.FailIfCertExpiresWithin,
+	}
+}
+
+// Run is the reusable worker-pool core shared by the CLI (Check) and the
+// server command: it starts cfg.Concurrency workers, dispatches urls to
+// them, and streams each Result back on the returned channel as soon as
+// it is available. The channel is closed once every URL has been
+// processed or ctx is canceled.
+func Run(ctx context.Context, urls []string, cfg Config) <-chan Result {
 	// Initialize a shared HTTP client.
 	// We use a default timeout of 10 seconds per individual request.
 	// Note: The global timeout is managed by the passed 'ctx'.
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout:   10 * time.Second,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
 	}
 
 	// Initialize the Rate Limiter if a limit is configured.
@@ -64,14 +125,12 @@ func Check(ctx context.Context, urls []string, cfg Config, outputFormat string)
 	results := make(chan Result, len(urls))
 	var wg sync.WaitGroup
 
-	slog.Info("Starting URL checks", "total_urls", len(urls), "workers", cfg.Concurrency, "retries", cfg.Retries, "rate_limit", cfg.RateLimit)
-	startTotal := time.Now()
-
 	// 1. Start the worker pool.
 	// We spawn 'cfg.Concurrency' goroutines to process URLs in parallel.
+	proberFor := ProberFor(client, cfg.probeOptions())
 	for i := 0; i < cfg.Concurrency; i++ {
 		wg.Add(1)
-		go worker(ctx, client, limiter, jobs, results, &wg, cfg.Retries)
+		go worker(ctx, proberFor, limiter, jobs, results, &wg, cfg.Retries, cfg.PerURLTimeout)
 	}
 
 	// 2. Dispatch jobs to the workers.
@@ -98,7 +157,25 @@ func Check(ctx context.Context, urls []string, cfg Config, outputFormat string)
 		close(results)
 	}()
 
-	// 4. Collect results and calculate statistics.
+	return results
+}
+
+// Check is the CLI entry point for the URL checking logic. It drives Run,
+// prints progress as results arrive (in text mode), and reports a final
+// summary or JSON report.
+//
+// Parameters:
+//   - ctx: Context for global cancellation and timeout.
+//   - urls: Slice of URL strings to check.
+//   - cfg: Configuration object containing concurrency, retry, and rate limit settings.
+//   - outputFormat: Format for the final output ("text" or "json").
+func Check(ctx context.Context, urls []string, cfg Config, outputFormat string) {
+	slog.Info("Starting URL checks", "total_urls", len(urls), "workers", cfg.Concurrency, "retries", cfg.Retries, "rate_limit", cfg.RateLimit)
+	startTotal := time.Now()
+
+	results := Run(ctx, urls, cfg)
+
+	// Collect results and calculate statistics.
 	// We iterate over the results channel as results come in.
 	var resultsList []Result
 	var okCount, failCount int
@@ -109,9 +186,8 @@ func Check(ctx context.Context, urls []string, cfg Config, outputFormat string)
 			printResult(res)
 		}
 
-		// Determine success vs failure.
-		// We consider a check successful if there is no error and the status code is 2xx.
-		if res.Err != nil || res.StatusCode < 200 || res.StatusCode >= 300 {
+		// Determine success vs failure, using protocol-appropriate semantics.
+		if res.Failed() {
 			failCount++
 		} else {
 			okCount++
@@ -119,9 +195,9 @@ func Check(ctx context.Context, urls []string, cfg Config, outputFormat string)
 		resultsList = append(resultsList, res)
 	}
 
-	// Check if the operation was terminated due to the global timeout.
-	if ctx.Err() == context.DeadlineExceeded {
-		slog.Error("Global timeout reached", "timeout", ctx.Err())
+	// Check if the operation was terminated due to cancellation, and if so, why.
+	if ctx.Err() != nil {
+		slog.Error("Checks stopped early", "cause", causeOf(ctx))
 	}
 
 	// If JSON output is requested, print the full report in JSON format and exit.
@@ -145,13 +221,14 @@ func Check(ctx context.Context, urls []string, cfg Config, outputFormat string)
 //
 // Parameters:
 //   - ctx: Context for cancellation.
-//   - client: Shared HTTP client.
+//   - proberFor: Selects the Prober to use for a given URL, based on its scheme.
 //   - limiter: Rate limiter (can be nil).
 //   - jobs: Channel to receive URLs from.
 //   - results: Channel to send results to.
 //   - wg: WaitGroup to signal completion.
 //   - maxRetries: Maximum number of retries allowed for failed requests.
-func worker(ctx context.Context, client *http.Client, limiter *rate.Limiter, jobs <-chan string, results chan<- Result, wg *sync.WaitGroup, maxRetries int) {
+//   - perURLTimeout: Per-attempt deadline applied to each probe call (0 = disabled).
+func worker(ctx context.Context, proberFor func(string) Prober, limiter *rate.Limiter, jobs <-chan string, results chan<- Result, wg *sync.WaitGroup, maxRetries int, perURLTimeout time.Duration) {
 	defer wg.Done()
 	for {
 		select {
@@ -166,80 +243,116 @@ func worker(ctx context.Context, client *http.Client, limiter *rate.Limiter, job
 
 			// Apply rate limiting if configured.
 			// Wait() blocks until the limiter allows the event to happen.
+			var rateLimitWait time.Duration
 			if limiter != nil {
+				waitStart := time.Now()
 				if err := limiter.Wait(ctx); err != nil {
-					// Context canceled while waiting.
+					// The wait was aborted (by cancellation, or because it
+					// would outlast the deadline); report why rather than
+					// silently dropping this URL from the results.
+					cause := causeOf(ctx)
+					if cause == nil {
+						cause = ErrRateLimitAborted
+					}
+					results <- Result{URL: url, Err: cause, ErrorMsg: cause.Error(), FailureReason: classifyCause(cause)}
 					return
 				}
+				rateLimitWait = time.Since(waitStart)
 			}
 
-			results <- checkURLWithRetries(ctx, client, url, maxRetries)
+			res := checkURLWithRetries(ctx, proberFor(url), url, maxRetries, perURLTimeout)
+			res.RateLimitWait = rateLimitWait
+			results <- res
 		}
 	}
 }
 
-// checkURLWithRetries performs the HTTP request with retry logic using exponential backoff.
-// It attempts to fetch the URL up to 'maxRetries' + 1 times.
-func checkURLWithRetries(ctx context.Context, client *http.Client, url string, maxRetries int) Result {
+// checkURLWithRetries runs prober against url with retry logic using
+// exponential backoff. It attempts the check up to 'maxRetries' + 1 times.
+// When perURLTimeout is positive, each attempt runs under its own child
+// deadline (cause ErrURLTimeout) independent of ctx's own deadline.
+func checkURLWithRetries(ctx context.Context, prober Prober, url string, maxRetries int, perURLTimeout time.Duration) Result {
 	var res Result
 	for i := 0; i <= maxRetries; i++ {
+		var backoff time.Duration
 		if i > 0 {
 			// Calculate exponential backoff delay: 500ms, 1s, 2s...
-			backoff := time.Duration(math.Pow(2, float64(i-1))) * 500 * time.Millisecond
+			backoff = time.Duration(math.Pow(2, float64(i-1))) * 500 * time.Millisecond
 			slog.Debug("Retrying request", "url", url, "attempt", i+1, "backoff", backoff)
 
 			// Wait for the backoff duration or context cancellation.
 			select {
 			case <-ctx.Done():
-				return Result{URL: url, Err: ctx.Err(), ErrorMsg: ctx.Err().Error()}
+				cause := causeOf(ctx)
+				return Result{URL: url, Err: cause, ErrorMsg: cause.Error(), FailureReason: classifyCause(cause)}
 			case <-time.After(backoff):
 			}
 		}
 
-		res = checkURL(ctx, client, url)
+		attemptCtx := ctx
+		var cancelAttempt context.CancelFunc
+		if perURLTimeout > 0 {
+			attemptCtx, cancelAttempt = context.WithTimeoutCause(ctx, perURLTimeout, ErrURLTimeout)
+		}
+
+		spanCtx, span := tracer.Start(attemptCtx, "checker.check_url",
+			trace.WithAttributes(
+				attribute.String("http.url", url),
+				attribute.Int("checker.attempt", i+1),
+				attribute.Int64("checker.retry_backoff_ms", backoff.Milliseconds()),
+			),
+		)
+
+		res = prober.Probe(spanCtx, url)
 		res.Retries = i
+		span.SetAttributes(attribute.String("checker.protocol", res.Protocol))
+		if res.Method != "" {
+			span.SetAttributes(attribute.String("http.method", res.Method))
+		}
 
-		// If the request was successful (2xx) or returned a 404 (which is a valid HTTP response),
-		// we consider it "done" and do not retry.
-		// We only retry on network errors or 5xx server errors.
-		if res.Err == nil && res.StatusCode < 500 {
+		if res.Err != nil {
+			switch {
+			case attemptCtx.Err() != nil:
+				cause := causeOf(attemptCtx)
+				res.Err = cause
+				res.ErrorMsg = cause.Error()
+				res.FailureReason = classifyCause(cause)
+			case errors.Is(res.Err, ErrCertExpiringSoon):
+				res.FailureReason = FailureCertExpiring
+			default:
+				res.FailureReason = FailureNetwork
+			}
+			span.RecordError(res.Err)
+			span.SetStatus(codes.Error, res.Err.Error())
+		} else if res.Protocol == "http" {
+			span.SetAttributes(attribute.Int("http.status_code", res.StatusCode))
+			switch {
+			case res.StatusCode >= 500:
+				res.FailureReason = FailureHTTP5xx
+				span.SetStatus(codes.Error, fmt.Sprintf("http %d", res.StatusCode))
+			case res.StatusCode >= 400:
+				res.FailureReason = FailureHTTP4xx
+			}
+		}
+		span.End()
+		if cancelAttempt != nil {
+			cancelAttempt()
+		}
+
+		// Stop once the attempt is "done": it succeeded, or it failed in a
+		// way that isn't worth retrying (see shouldRetry).
+		if !shouldRetry(res) {
 			return res
 		}
 	}
 	return res
 }
 
-// checkURL performs a single HTTP GET request.
-// It wraps the request in the provided context to support cancellation.
+// checkURL performs a single plain HTTP GET request, with no retries or
+// protocol dispatch. It exists alongside the Prober-based path for callers
+// (and tests) that just want to probe one http(s) URL directly.
 func checkURL(ctx context.Context, client *http.Client, url string) Result {
-	start := time.Now()
-
-	// Create a new request with the provided context.
-	// This ensures that if the global context is canceled, the in-flight request is aborted.
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return Result{URL: url, Duration: time.Since(start), Err: err, ErrorMsg: err.Error()}
-	}
-
-	resp, err := client.Do(req)
-	duration := time.Since(start)
-
-	if err != nil {
-		return Result{
-			URL:      url,
-			Duration: duration,
-			Err:      err,
-			ErrorMsg: err.Error(),
-		}
-	}
-	defer resp.Body.Close()
-
-	return Result{
-		URL:        url,
-		StatusCode: resp.StatusCode,
-		Duration:   duration,
-		Err:        nil,
-	}
+	return httpProber{client: client}.Probe(ctx, url)
 }
 
 // printJSON formats and prints the entire result set as a JSON object.
@@ -0,0 +1,180 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Prober performs a single check against a target and returns a Result.
+// The worker pool selects the concrete implementation via ProberFor and
+// hands it to checkURLWithRetries, so the retry/backoff logic stays
+// protocol-agnostic.
+type Prober interface {
+	Probe(ctx context.Context, target string) Result
+}
+
+// ProbeOptions configures protocol-specific probers.
+type ProbeOptions struct {
+	// Method, Body and Headers configure the HTTP prober. Method defaults
+	// to GET when empty.
+	Method  string
+	Body    string
+	Headers http.Header
+	// FailIfCertExpiresWithin marks a tls:// probe as failed when the
+	// leaf certificate's NotAfter is closer than this duration.
+	FailIfCertExpiresWithin time.Duration
+}
+
+// ProberFor returns the Prober that should handle target, based on its
+// scheme: tcp://, tls:// and dns:// select the matching specialized
+// prober; anything else is treated as an HTTP(S) URL.
+func ProberFor(client *http.Client, opts ProbeOptions) func(target string) Prober {
+	return func(target string) Prober {
+		switch {
+		case strings.HasPrefix(target, "tcp://"):
+			return tcpProber{}
+		case strings.HasPrefix(target, "tls://"):
+			return tlsProber{failIfExpiresWithin: opts.FailIfCertExpiresWithin}
+		case strings.HasPrefix(target, "dns://"):
+			return dnsProber{}
+		default:
+			return httpProber{client: client, method: opts.Method, body: opts.Body, headers: opts.Headers}
+		}
+	}
+}
+
+// httpProber issues a single HTTP request (GET by default; HEAD/POST/etc.
+// via Method) with an optional body and extra headers.
+type httpProber struct {
+	client  *http.Client
+	method  string
+	body    string
+	headers http.Header
+}
+
+func (p httpProber) Probe(ctx context.Context, target string) Result {
+	start := time.Now()
+	method := p.method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	var bodyReader *strings.Reader
+	if p.body != "" {
+		bodyReader = strings.NewReader(p.body)
+	}
+
+	var req *http.Request
+	var err error
+	if bodyReader != nil {
+		req, err = http.NewRequestWithContext(ctx, method, target, bodyReader)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, method, target, nil)
+	}
+	if err != nil {
+		return Result{URL: target, Protocol: "http", Method: method, Duration: time.Since(start), Err: err, ErrorMsg: err.Error()}
+	}
+	for name, values := range p.headers {
+		for _, v := range values {
+			req.Header.Add(name, v)
+		}
+	}
+
+	resp, err := p.client.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		return Result{URL: target, Protocol: "http", Method: method, Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	return Result{URL: target, Protocol: "http", Method: method, StatusCode: resp.StatusCode, Duration: duration}
+}
+
+// tcpProber reports whether a TCP connection to target can be established.
+type tcpProber struct{}
+
+func (p tcpProber) Probe(ctx context.Context, target string) Result {
+	start := time.Now()
+	addr := strings.TrimPrefix(target, "tcp://")
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	duration := time.Since(start)
+	if err != nil {
+		return Result{URL: target, Protocol: "tcp", Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+	defer conn.Close()
+
+	return Result{URL: target, Protocol: "tcp", Duration: duration}
+}
+
+// tlsProber dials target over TLS and reports the leaf certificate's
+// expiry, optionally failing the check when it expires too soon.
+type tlsProber struct {
+	failIfExpiresWithin time.Duration
+	// rootCAs overrides the trust store used to verify the server's
+	// certificate chain. Left nil (and so defaulting to the system pool)
+	// in production; tests set it to trust a local test server's cert.
+	rootCAs *x509.CertPool
+}
+
+func (p tlsProber) Probe(ctx context.Context, target string) Result {
+	start := time.Now()
+	addr := strings.TrimPrefix(target, "tls://")
+
+	dialer := &tls.Dialer{NetDialer: &net.Dialer{}, Config: &tls.Config{RootCAs: p.rootCAs}}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	duration := time.Since(start)
+	if err != nil {
+		return Result{URL: target, Protocol: "tls", Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+	defer conn.Close()
+
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		err := errors.New("tls: established connection is not a *tls.Conn")
+		return Result{URL: target, Protocol: "tls", Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+
+	certs := tlsConn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		err := errors.New("tls: server presented no certificates")
+		return Result{URL: target, Protocol: "tls", Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+
+	notAfter := certs[0].NotAfter
+	res := Result{URL: target, Protocol: "tls", Duration: duration, TLSNotAfter: &notAfter}
+	if p.failIfExpiresWithin > 0 && time.Until(notAfter) < p.failIfExpiresWithin {
+		res.Err = fmt.Errorf("tls: certificate for %s expires %s, within the %s threshold: %w", addr, notAfter.Format(time.RFC3339), p.failIfExpiresWithin, ErrCertExpiringSoon)
+		res.ErrorMsg = res.Err.Error()
+	}
+	return res
+}
+
+// dnsProber resolves target's A/AAAA records.
+type dnsProber struct{}
+
+func (p dnsProber) Probe(ctx context.Context, target string) Result {
+	start := time.Now()
+	host := strings.TrimPrefix(target, "dns://")
+
+	var resolver net.Resolver
+	addrs, err := resolver.LookupIPAddr(ctx, host)
+	duration := time.Since(start)
+	if err != nil {
+		return Result{URL: target, Protocol: "dns", Duration: duration, Err: err, ErrorMsg: err.Error()}
+	}
+
+	answers := make([]string, len(addrs))
+	for i, addr := range addrs {
+		answers[i] = addr.String()
+	}
+	return Result{URL: target, Protocol: "dns", Duration: duration, DNSAnswers: answers}
+}
@@ -0,0 +1,173 @@
+package checker
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTLSRootCAs returns a pool trusting only server's own certificate, so
+// tlsProber can verify it without relying on the system trust store.
+func testTLSRootCAs(server *httptest.Server) *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return pool
+}
+
+func TestHTTPProber_MethodBodyHeaders(t *testing.T) {
+	var gotMethod, gotBody, gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		body := make([]byte, r.ContentLength)
+		_, _ = r.Body.Read(body)
+		gotBody = string(body)
+		gotHeader = r.Header.Get("X-Test")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	prober := httpProber{
+		client:  server.Client(),
+		method:  http.MethodPost,
+		body:    "hello",
+		headers: http.Header{"X-Test": []string{"yes"}},
+	}
+
+	res := prober.Probe(context.Background(), server.URL)
+
+	if res.Err != nil {
+		t.Fatalf("Expected no error, got: %v", res.Err)
+	}
+	if res.StatusCode != http.StatusCreated {
+		t.Errorf("Expected status code 201, got: %d", res.StatusCode)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("Expected method POST, got: %s", gotMethod)
+	}
+	if gotBody != "hello" {
+		t.Errorf("Expected body %q, got: %q", "hello", gotBody)
+	}
+	if gotHeader != "yes" {
+		t.Errorf("Expected header X-Test=yes, got: %q", gotHeader)
+	}
+}
+
+func TestHTTPProber_DefaultsToGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected default method GET, got: %s", r.Method)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	prober := httpProber{client: server.Client()}
+	res := prober.Probe(context.Background(), server.URL)
+
+	if res.Err != nil {
+		t.Fatalf("Expected no error, got: %v", res.Err)
+	}
+}
+
+func TestTCPProber_Success(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start listener: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	res := tcpProber{}.Probe(context.Background(), "tcp://"+ln.Addr().String())
+
+	if res.Err != nil {
+		t.Errorf("Expected no error, got: %v", res.Err)
+	}
+	if res.Protocol != "tcp" {
+		t.Errorf("Expected protocol tcp, got: %s", res.Protocol)
+	}
+}
+
+func TestTCPProber_ConnectionRefused(t *testing.T) {
+	res := tcpProber{}.Probe(context.Background(), "tcp://127.0.0.1:1")
+
+	if res.Err == nil {
+		t.Error("Expected connection error, got nil")
+	}
+}
+
+func TestTLSProber_ReportsExpiryWithoutThreshold(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	addr := strings.TrimPrefix(server.URL, "https://")
+	prober := tlsProber{rootCAs: testTLSRootCAs(server)}
+	res := prober.Probe(context.Background(), "tls://"+addr)
+
+	if res.Err != nil {
+		t.Fatalf("Expected no error, got: %v", res.Err)
+	}
+	if res.TLSNotAfter == nil {
+		t.Fatal("Expected TLSNotAfter to be set")
+	}
+}
+
+func TestTLSProber_FailsWhenWithinExpiryThreshold(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	addr := strings.TrimPrefix(server.URL, "https://")
+	// httptest's generated certificate expires in 2084, so only a
+	// threshold longer than its remaining lifetime is guaranteed to trip.
+	prober := tlsProber{
+		failIfExpiresWithin: 100 * 365 * 24 * time.Hour,
+		rootCAs:             testTLSRootCAs(server),
+	}
+	res := prober.Probe(context.Background(), "tls://"+addr)
+
+	if res.Err == nil {
+		t.Fatal("Expected cert-expiry error, got nil")
+	}
+	if !errors.Is(res.Err, ErrCertExpiringSoon) {
+		t.Errorf("Expected error to wrap ErrCertExpiringSoon, got: %v", res.Err)
+	}
+	if shouldRetry(res) {
+		t.Error("Expected a cert-expiry breach to be non-retryable")
+	}
+}
+
+func TestDNSProber_Success(t *testing.T) {
+	res := dnsProber{}.Probe(context.Background(), "dns://localhost")
+
+	if res.Err != nil {
+		t.Errorf("Expected no error, got: %v", res.Err)
+	}
+	if len(res.DNSAnswers) == 0 {
+		t.Error("Expected at least one DNS answer for localhost")
+	}
+}
+
+func TestDNSProber_NXDOMAIN(t *testing.T) {
+	res := dnsProber{}.Probe(context.Background(), "dns://this-domain-should-not-resolve.invalid")
+
+	if res.Err == nil {
+		t.Error("Expected resolution error, got nil")
+	}
+}
@@ -0,0 +1,223 @@
+package checker
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Decorrelated-jitter backoff bounds used between wait rounds, modeled on
+// the algorithm from AWS's "Exponential Backoff And Jitter" post:
+// sleep = min(cap, random_between(base, prev*3)).
+const (
+	waitBackoffBase = 500 * time.Millisecond
+	waitBackoffCap  = 30 * time.Second
+)
+
+// SuccessPredicate decides whether a single probe of a URL counts as
+// healthy. An unset field is not checked, except the status code, which
+// must be 2xx when ExpectStatus is empty.
+type SuccessPredicate struct {
+	ExpectStatus      []int          // acceptable status codes; empty means any 2xx
+	ExpectBodyRegex   *regexp.Regexp // response body must match, if set
+	ExpectHeaderName  string         // header checked against ExpectHeaderRegex, if set
+	ExpectHeaderRegex *regexp.Regexp
+	MinConsecutive    int // consecutive successes required before a URL is healthy; <1 means 1
+}
+
+func (p SuccessPredicate) minConsecutive() int {
+	if p.MinConsecutive < 1 {
+		return 1
+	}
+	return p.MinConsecutive
+}
+
+// matches reports whether resp (and body, if ExpectBodyRegex is set)
+// satisfies the predicate.
+func (p SuccessPredicate) matches(resp *http.Response, body []byte) bool {
+	if len(p.ExpectStatus) > 0 {
+		ok := false
+		for _, s := range p.ExpectStatus {
+			if resp.StatusCode == s {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	} else if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false
+	}
+
+	if p.ExpectBodyRegex != nil && !p.ExpectBodyRegex.Match(body) {
+		return false
+	}
+
+	if p.ExpectHeaderRegex != nil && !p.ExpectHeaderRegex.MatchString(resp.Header.Get(p.ExpectHeaderName)) {
+		return false
+	}
+
+	return true
+}
+
+// WaitResult is the outcome of waiting for a single URL to become healthy.
+type WaitResult struct {
+	URL            string     `json:"url"`
+	Attempts       int        `json:"attempts"`
+	BecameHealthy  bool       `json:"became_healthy"`
+	FirstSuccessAt *time.Time `json:"first_success_at,omitempty"`
+	LastResult     Result     `json:"last_result"`
+}
+
+// WaiterConfig controls a Waiter's concurrency and health predicate.
+type WaiterConfig struct {
+	Concurrency int // number of URLs polled concurrently; <1 means 1
+	WaitTimeout time.Duration
+	Predicate   SuccessPredicate
+}
+
+// Waiter repeatedly re-checks URLs that haven't yet satisfied a
+// SuccessPredicate, modeled on deployment health-waiters: it is used by
+// the CLI's --wait mode to gate CI/CD pipelines on every URL becoming
+// healthy rather than just checking each one once.
+type Waiter struct {
+	client *http.Client
+	cfg    WaiterConfig
+}
+
+// NewWaiter builds a Waiter with its own HTTP client, instrumented the
+// same way as the one-shot checker.
+func NewWaiter(cfg WaiterConfig) *Waiter {
+	return &Waiter{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		cfg: cfg,
+	}
+}
+
+// Wait polls every url until it becomes healthy or cfg.WaitTimeout elapses,
+// returning one WaitResult per url in the same order they were given.
+func (w *Waiter) Wait(ctx context.Context, urls []string) []WaitResult {
+	waitCtx, cancel := context.WithTimeoutCause(ctx, w.cfg.WaitTimeout, ErrGlobalTimeout)
+	defer cancel()
+
+	concurrency := w.cfg.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	results := make([]WaitResult, len(urls))
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = w.waitForURL(waitCtx, url)
+		}(i, url)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// waitForURL polls a single URL, backing off between rounds, until the
+// predicate has matched MinConsecutive times in a row or ctx is done.
+func (w *Waiter) waitForURL(ctx context.Context, url string) WaitResult {
+	res := WaitResult{URL: url}
+	consecutive := 0
+	backoff := waitBackoffBase
+
+	for {
+		res.Attempts++
+		last, healthy := w.probe(ctx, url)
+		res.LastResult = last
+
+		if healthy {
+			consecutive++
+			if res.FirstSuccessAt == nil {
+				t := time.Now()
+				res.FirstSuccessAt = &t
+			}
+			if consecutive >= w.cfg.Predicate.minConsecutive() {
+				res.BecameHealthy = true
+				return res
+			}
+		} else {
+			consecutive = 0
+			res.FirstSuccessAt = nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return res
+		case <-time.After(backoff):
+			backoff = nextDecorrelatedJitter(backoff)
+		}
+	}
+}
+
+// probe performs a single health check against url.
+func (w *Waiter) probe(ctx context.Context, url string) (Result, bool) {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return Result{URL: url, Duration: time.Since(start), Err: err, ErrorMsg: err.Error(), FailureReason: FailureNetwork}, false
+	}
+
+	resp, err := w.client.Do(req)
+	duration := time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			cause := causeOf(ctx)
+			return Result{URL: url, Duration: duration, Err: cause, ErrorMsg: cause.Error(), FailureReason: classifyCause(cause)}, false
+		}
+		return Result{URL: url, Duration: duration, Err: err, ErrorMsg: err.Error(), FailureReason: FailureNetwork}, false
+	}
+	defer resp.Body.Close()
+
+	var body []byte
+	if w.cfg.Predicate.ExpectBodyRegex != nil {
+		body, _ = io.ReadAll(resp.Body)
+	}
+
+	res := Result{URL: url, StatusCode: resp.StatusCode, Duration: duration}
+	healthy := w.cfg.Predicate.matches(resp, body)
+	if !healthy {
+		switch {
+		case resp.StatusCode >= 500:
+			res.FailureReason = FailureHTTP5xx
+		case resp.StatusCode >= 400:
+			res.FailureReason = FailureHTTP4xx
+		}
+	}
+	return res, healthy
+}
+
+// nextDecorrelatedJitter computes the next backoff from prev using
+// decorrelated jitter: sleep = min(cap, random_between(base, prev*3)).
+func nextDecorrelatedJitter(prev time.Duration) time.Duration {
+	upper := prev * 3
+	if upper < waitBackoffBase {
+		upper = waitBackoffBase
+	}
+	if upper > waitBackoffCap {
+		upper = waitBackoffCap
+	}
+	next := waitBackoffBase + time.Duration(rand.Int63n(int64(upper-waitBackoffBase)+1))
+	if next > waitBackoffCap {
+		next = waitBackoffCap
+	}
+	return next
+}
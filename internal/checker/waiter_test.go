@@ -0,0 +1,157 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNextDecorrelatedJitter_StaysWithinBounds(t *testing.T) {
+	prev := waitBackoffBase
+	for i := 0; i < 1000; i++ {
+		next := nextDecorrelatedJitter(prev)
+		if next < waitBackoffBase {
+			t.Fatalf("Expected next >= %s, got: %s", waitBackoffBase, next)
+		}
+		if next > waitBackoffCap {
+			t.Fatalf("Expected next <= %s, got: %s", waitBackoffCap, next)
+		}
+		prev = next
+	}
+}
+
+func TestNextDecorrelatedJitter_NeverExceedsCap(t *testing.T) {
+	// A huge prev should still clamp to the cap, not prev*3.
+	next := nextDecorrelatedJitter(waitBackoffCap * 100)
+	if next > waitBackoffCap {
+		t.Errorf("Expected result clamped to %s, got: %s", waitBackoffCap, next)
+	}
+}
+
+func TestSuccessPredicate_Matches_DefaultAny2xx(t *testing.T) {
+	p := SuccessPredicate{}
+
+	ok := p.matches(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil)
+	if !ok {
+		t.Error("Expected 200 to match the default any-2xx predicate")
+	}
+
+	bad := p.matches(&http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}, nil)
+	if bad {
+		t.Error("Expected 404 not to match the default any-2xx predicate")
+	}
+}
+
+func TestSuccessPredicate_Matches_ExplicitStatusList(t *testing.T) {
+	p := SuccessPredicate{ExpectStatus: []int{201, 202}}
+
+	if !p.matches(&http.Response{StatusCode: 201, Header: http.Header{}}, nil) {
+		t.Error("Expected 201 to match an explicit ExpectStatus list containing it")
+	}
+	if p.matches(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, nil) {
+		t.Error("Expected 200 not to match an explicit ExpectStatus list that excludes it")
+	}
+}
+
+func TestSuccessPredicate_Matches_BodyRegex(t *testing.T) {
+	p := SuccessPredicate{ExpectBodyRegex: regexp.MustCompile(`^ready$`)}
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}}
+
+	if !p.matches(resp, []byte("ready")) {
+		t.Error("Expected body matching the regex to pass")
+	}
+	if p.matches(resp, []byte("starting")) {
+		t.Error("Expected body not matching the regex to fail")
+	}
+}
+
+func TestSuccessPredicate_Matches_Header(t *testing.T) {
+	p := SuccessPredicate{ExpectHeaderName: "X-Ready", ExpectHeaderRegex: regexp.MustCompile(`^true$`)}
+
+	ok := p.matches(&http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Ready": []string{"true"}}}, nil)
+	if !ok {
+		t.Error("Expected matching header to pass")
+	}
+	bad := p.matches(&http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Ready": []string{"false"}}}, nil)
+	if bad {
+		t.Error("Expected non-matching header to fail")
+	}
+}
+
+func TestSuccessPredicate_Matches_AllConditionsRequired(t *testing.T) {
+	p := SuccessPredicate{
+		ExpectStatus:      []int{200},
+		ExpectBodyRegex:   regexp.MustCompile(`^ready$`),
+		ExpectHeaderName:  "X-Ready",
+		ExpectHeaderRegex: regexp.MustCompile(`^true$`),
+	}
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{"X-Ready": []string{"true"}}}
+
+	if !p.matches(resp, []byte("ready")) {
+		t.Error("Expected all conditions satisfied to match")
+	}
+	if p.matches(resp, []byte("not ready")) {
+		t.Error("Expected a body mismatch to fail even when status and header match")
+	}
+}
+
+func TestWaiter_WaitForURL_ResetsConsecutiveCountOnFailure(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1, 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	waiter := NewWaiter(WaiterConfig{
+		Concurrency: 1,
+		WaitTimeout: 10 * time.Second,
+		Predicate:   SuccessPredicate{MinConsecutive: 2},
+	})
+
+	results := waiter.Wait(context.Background(), []string{server.URL})
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got: %d", len(results))
+	}
+
+	res := results[0]
+	if !res.BecameHealthy {
+		t.Fatal("Expected the URL to eventually become healthy")
+	}
+	// Calls: fail, ok (consecutive=1), fail (reset), ok, ok (consecutive=2 -> healthy).
+	if calls != 5 {
+		t.Errorf("Expected the intervening failure to reset the consecutive-success count (5 calls), got: %d", calls)
+	}
+	if res.Attempts != 5 {
+		t.Errorf("Expected 5 attempts, got: %d", res.Attempts)
+	}
+}
+
+func TestWaiter_Wait_TimesOutWhenNeverHealthy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	waiter := NewWaiter(WaiterConfig{
+		Concurrency: 1,
+		WaitTimeout: 600 * time.Millisecond,
+		Predicate:   SuccessPredicate{},
+	})
+
+	results := waiter.Wait(context.Background(), []string{server.URL})
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got: %d", len(results))
+	}
+	if results[0].BecameHealthy {
+		t.Error("Expected the URL to never become healthy before the wait timeout")
+	}
+}
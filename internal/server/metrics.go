@@ -0,0 +1,67 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sudosantos27/go-url-checker/internal/checker"
+)
+
+// metrics holds the Prometheus collectors exposed on /metrics.
+type metrics struct {
+	requestsTotal   *prometheus.CounterVec
+	statusHistogram *prometheus.HistogramVec
+	rateLimitWaits  prometheus.Histogram
+	retriesTotal    prometheus.Counter
+}
+
+// newMetrics registers the checker's Prometheus collectors on reg.
+func newMetrics(reg prometheus.Registerer) *metrics {
+	factory := promauto.With(reg)
+	return &metrics{
+		requestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "url_checker_requests_total",
+			Help: "Total number of per-URL checks handled by the server (one per URL, after retries).",
+		}, []string{"outcome"}),
+		statusHistogram: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "url_checker_check_duration_seconds",
+			Help:    "Duration of the final attempt of each per-URL check, labeled by HTTP status class.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"status_class"}),
+		rateLimitWaits: factory.NewHistogram(prometheus.HistogramOpts{
+			Name:    "url_checker_rate_limit_wait_seconds",
+			Help:    "Time spent waiting on the configured rate limiter before a check ran.",
+			Buckets: prometheus.DefBuckets,
+		}),
+		retriesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "url_checker_retries_total",
+			Help: "Total number of retry attempts performed across all checks.",
+		}),
+	}
+}
+
+// observe records a single checker.Result against the registered collectors.
+func (m *metrics) observe(res checker.Result) {
+	outcome := "ok"
+	if res.Failed() {
+		outcome = "fail"
+	}
+	m.requestsTotal.WithLabelValues(outcome).Inc()
+	m.statusHistogram.WithLabelValues(statusClass(res.StatusCode)).Observe(res.Duration.Seconds())
+	if res.RateLimitWait > 0 {
+		m.rateLimitWaits.Observe(res.RateLimitWait.Seconds())
+	}
+	if res.Retries > 0 {
+		m.retriesTotal.Add(float64(res.Retries))
+	}
+}
+
+// statusClass buckets an HTTP status code into its "Nxx" class, or "err"
+// when the request never produced a status code.
+func statusClass(status int) string {
+	if status == 0 {
+		return "err"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
@@ -0,0 +1,238 @@
+// Package server exposes the existing checker as a long-running HTTP
+// service: a synchronous /v1/check endpoint, an NDJSON streaming variant,
+// and the usual /metrics and /healthz endpoints.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sudosantos27/go-url-checker/internal/checker"
+)
+
+// defaultRequestTimeout bounds how long a /v1/check request may run when
+// the caller does not specify one.
+const defaultRequestTimeout = 30 * time.Second
+
+// maxRequestBodyBytes caps the size of a /v1/check(/stream) request body,
+// so an unauthenticated client can't exhaust memory with a huge payload.
+const maxRequestBodyBytes = 1 << 20 // 1 MiB
+
+// maxRequestURLs caps how many URLs a single request may check, and
+// maxRequestConcurrency caps the concurrency it may ask for, so a client
+// can't make the server spawn an unbounded number of goroutines.
+const (
+	maxRequestURLs        = 10_000
+	maxRequestConcurrency = 500
+)
+
+// Config controls how the server binds and authenticates.
+type Config struct {
+	Addr      string // e.g. ":8080"
+	AuthToken string // if non-empty, required as a Bearer token on /v1/check and /v1/check/stream (/metrics and /healthz stay open)
+}
+
+// Server serves the checker over HTTP.
+type Server struct {
+	cfg     Config
+	metrics *metrics
+	http    *http.Server
+}
+
+// New builds a Server ready to Run. Prometheus collectors are registered
+// against prometheus.DefaultRegisterer.
+func New(cfg Config) *Server {
+	return newServer(cfg, prometheus.DefaultRegisterer)
+}
+
+// newServer builds a Server registering its Prometheus collectors against
+// reg. Split out from New so tests can pass a private prometheus.Registry
+// instead of colliding with other tests on the global DefaultRegisterer.
+func newServer(cfg Config, reg prometheus.Registerer) *Server {
+	s := &Server{
+		cfg:     cfg,
+		metrics: newMetrics(reg),
+	}
+	s.http = &http.Server{
+		Addr:    cfg.Addr,
+		Handler: s.routes(),
+	}
+	return s
+}
+
+// routes builds the server's handler tree.
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/check", s.withAuth(s.handleCheck))
+	mux.HandleFunc("/v1/check/stream", s.withAuth(s.handleCheckStream))
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	return mux
+}
+
+// Run starts the HTTP listener and blocks until ctx is canceled, at which
+// point it drains in-flight checks and shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("Server listening", "addr", s.cfg.Addr)
+		if err := s.http.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+			return
+		}
+		errCh <- nil
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		slog.Info("Shutting down server, draining in-flight checks")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return s.http.Shutdown(shutdownCtx)
+	}
+}
+
+// withAuth rejects requests without a matching bearer token, when one is configured.
+func (s *Server) withAuth(next http.HandlerFunc) http.HandlerFunc {
+	if s.cfg.AuthToken == "" {
+		return next
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if token == "" || token != s.cfg.AuthToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// checkRequest is the payload accepted by /v1/check and /v1/check/stream.
+type checkRequest struct {
+	URLs        []string      `json:"urls"`
+	Concurrency int           `json:"concurrency"`
+	Retries     int           `json:"retries"`
+	RateLimit   int           `json:"rate_limit"`
+	Timeout     time.Duration `json:"timeout"`
+}
+
+// config converts the request into a checker.Config, raising a concurrency
+// below 1 to 1 (as the CLI does) and clamping it to maxRequestConcurrency
+// so a client can't force the server to spawn an unbounded worker pool.
+func (req checkRequest) config() checker.Config {
+	concurrency := req.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > maxRequestConcurrency {
+		concurrency = maxRequestConcurrency
+	}
+	return checker.Config{
+		Concurrency: concurrency,
+		Retries:     req.Retries,
+		RateLimit:   req.RateLimit,
+	}
+}
+
+// decodeCheckRequest reads and validates a checkRequest from r, writing an
+// appropriate error response and returning ok=false if the body is too
+// large, malformed, empty, or asks to check more than maxRequestURLs.
+func decodeCheckRequest(w http.ResponseWriter, r *http.Request) (checkRequest, bool) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodyBytes)
+
+	var req checkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+	if len(req.URLs) == 0 {
+		http.Error(w, "urls must not be empty", http.StatusBadRequest)
+		return req, false
+	}
+	if len(req.URLs) > maxRequestURLs {
+		http.Error(w, fmt.Sprintf("urls must not exceed %d", maxRequestURLs), http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
+
+// requestContext derives a context bounded by the request's timeout (or
+// defaultRequestTimeout when unset).
+func (req checkRequest) requestContext(parent context.Context) (context.Context, context.CancelFunc) {
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// handleCheck runs the checker to completion and returns the full result
+// list as a single JSON array of Results (the CLI's --output=json wraps
+// the same Results in an object with a summary; this endpoint does not).
+func (s *Server) handleCheck(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCheckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := req.requestContext(r.Context())
+	defer cancel()
+
+	var results []checker.Result
+	for res := range checker.Run(ctx, req.URLs, req.config()) {
+		s.metrics.observe(res)
+		results = append(results, res)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		slog.Error("Encoding check response", "error", err)
+	}
+}
+
+// handleCheckStream runs the checker and writes each Result as an NDJSON
+// line as soon as it is available, flushing after every write.
+func (s *Server) handleCheckStream(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeCheckRequest(w, r)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := req.requestContext(r.Context())
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	for res := range checker.Run(ctx, req.URLs, req.config()) {
+		s.metrics.observe(res)
+		if err := enc.Encode(res); err != nil {
+			slog.Error("Encoding streamed result", "error", err)
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+// handleHealthz is a trivial liveness probe.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
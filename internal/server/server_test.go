@@ -0,0 +1,229 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// newTestServer builds a Server against a private registry so multiple
+// tests in this file don't collide on prometheus.DefaultRegisterer.
+func newTestServer(cfg Config) *Server {
+	return newServer(cfg, prometheus.NewRegistry())
+}
+
+func TestWithAuth_RejectsMissingOrWrongToken(t *testing.T) {
+	srv := newTestServer(Config{AuthToken: "secret"})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	body := strings.NewReader(`{"urls":["http://example.com"]}`)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/check", body)
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with no token, got: %d", resp.StatusCode)
+	}
+
+	body = strings.NewReader(`{"urls":["http://example.com"]}`)
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+"/v1/check", body)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err = ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with wrong token, got: %d", resp.StatusCode)
+	}
+}
+
+func TestWithAuth_AcceptsMatchingToken(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	srv := newTestServer(Config{AuthToken: "secret"})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	body := strings.NewReader(`{"urls":["` + backend.URL + `"]}`)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/check", body)
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200 with matching token, got: %d", resp.StatusCode)
+	}
+}
+
+func TestWithAuth_NoTokenConfiguredAllowsAll(t *testing.T) {
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	body := strings.NewReader(`{"urls":["http://example.com"]}`)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+"/v1/check", body)
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized {
+		t.Error("Expected request to be allowed when no AuthToken is configured")
+	}
+}
+
+func TestHandleCheck_HappyPath(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	body := strings.NewReader(`{"urls":["` + backend.URL + `"]}`)
+	resp, err := ts.Client().Post(ts.URL+"/v1/check", "application/json", body)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got: %d", resp.StatusCode)
+	}
+
+	var results []map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("Expected 1 result, got: %d", len(results))
+	}
+	if results[0]["status_code"].(float64) != http.StatusOK {
+		t.Errorf("Expected status_code 200, got: %v", results[0]["status_code"])
+	}
+}
+
+func TestHandleCheck_RejectsEmptyURLs(t *testing.T) {
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	resp, err := ts.Client().Post(ts.URL+"/v1/check", "application/json", strings.NewReader(`{"urls":[]}`))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for empty urls, got: %d", resp.StatusCode)
+	}
+}
+
+func TestHandleCheck_RejectsTooManyURLs(t *testing.T) {
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	urls := make([]string, maxRequestURLs+1)
+	for i := range urls {
+		urls[i] = `"http://example.com"`
+	}
+	payload := `{"urls":[` + strings.Join(urls, ",") + `]}`
+
+	resp, err := ts.Client().Post(ts.URL+"/v1/check", "application/json", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for too many urls, got: %d", resp.StatusCode)
+	}
+}
+
+func TestHandleCheck_RejectsOversizedBody(t *testing.T) {
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	oversized := bytes.Repeat([]byte("a"), maxRequestBodyBytes+1)
+	payload := `{"urls":["http://example.com"],"padding":"` + string(oversized) + `"}`
+
+	resp, err := ts.Client().Post(ts.URL+"/v1/check", "application/json", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an oversized body, got: %d", resp.StatusCode)
+	}
+}
+
+func TestHandleCheckStream_WritesNDJSONPerURL(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	body := strings.NewReader(`{"urls":["` + backend.URL + `"]}`)
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/v1/check/stream", body)
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got: %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Expected Content-Type application/x-ndjson, got: %q", ct)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	var lines int
+	for scanner.Scan() {
+		var res map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &res); err != nil {
+			t.Fatalf("Failed to decode NDJSON line %q: %v", scanner.Text(), err)
+		}
+		lines++
+	}
+	if lines != 1 {
+		t.Errorf("Expected 1 NDJSON line, got: %d", lines)
+	}
+}
+
+func TestHandleHealthz(t *testing.T) {
+	srv := newTestServer(Config{})
+	ts := httptest.NewServer(srv.routes())
+	defer ts.Close()
+
+	resp, err := ts.Client().Get(ts.URL + "/healthz")
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected 200, got: %d", resp.StatusCode)
+	}
+}
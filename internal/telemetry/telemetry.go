@@ -0,0 +1,82 @@
+// Package telemetry wires up OpenTelemetry tracing for go-url-checker.
+// It centralizes TracerProvider construction so every CLI command
+// (including serve) shares the same setup.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// ServiceName identifies this binary in exported spans.
+const ServiceName = "go-url-checker"
+
+// Config controls how the global TracerProvider is initialized.
+type Config struct {
+	// Exporter selects the span exporter: "otlp", "stdout", or "none".
+	Exporter string
+	// Endpoint is the OTLP collector address (host:port), used when
+	// Exporter is "otlp".
+	Endpoint string
+	// SampleRatio is the fraction of root spans that are sampled, in [0,1].
+	SampleRatio float64
+}
+
+// noopShutdown is returned when tracing is disabled so callers can always
+// defer the returned shutdown func unconditionally.
+func noopShutdown(context.Context) error { return nil }
+
+// Init configures a global TracerProvider according to cfg and registers
+// the W3C trace-context propagator so outgoing HTTP requests carry
+// traceparent headers. It returns a shutdown func that must be called
+// before the process exits to flush any buffered spans.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	if cfg.Exporter == "" || cfg.Exporter == "none" {
+		return noopShutdown, nil
+	}
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch cfg.Exporter {
+	case "otlp":
+		exporter, err = otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(cfg.Endpoint),
+			otlptracegrpc.WithInsecure(),
+		)
+	case "stdout":
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("telemetry: unknown exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: creating %s exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceNameKey.String(ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("telemetry: building resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return tp.Shutdown, nil
+}
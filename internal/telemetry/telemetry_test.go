@@ -0,0 +1,49 @@
+package telemetry
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInit_EmptyExporterIsNoop(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{})
+	if err != nil {
+		t.Fatalf("Expected no error for an empty Exporter, got: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected the noop shutdown to succeed, got: %v", err)
+	}
+}
+
+func TestInit_NoneExporterIsNoop(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{Exporter: "none"})
+	if err != nil {
+		t.Fatalf("Expected no error for Exporter \"none\", got: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected the noop shutdown to succeed, got: %v", err)
+	}
+}
+
+func TestInit_UnknownExporterErrors(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{Exporter: "carrier-pigeon"})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown exporter, got nil")
+	}
+	if shutdown != nil {
+		t.Error("Expected a nil shutdown func alongside the error")
+	}
+}
+
+func TestInit_StdoutExporterSucceeds(t *testing.T) {
+	shutdown, err := Init(context.Background(), Config{Exporter: "stdout", SampleRatio: 1.0})
+	if err != nil {
+		t.Fatalf("Expected no error for the stdout exporter, got: %v", err)
+	}
+	if shutdown == nil {
+		t.Fatal("Expected a non-nil shutdown func")
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Expected shutdown to succeed, got: %v", err)
+	}
+}